@@ -18,12 +18,13 @@
 package release
 
 import (
+	"os"
 	"strings"
 
 	"github.com/mysteriumnetwork/go-ci/env"
 	"github.com/mysteriumnetwork/go-ci/job"
-	"github.com/mysteriumnetwork/go-ci/shell"
-	"github.com/mysteriumnetwork/node/ci/deb"
+	"github.com/mysteriumnetwork/node/ci/release/releasepb"
+	"github.com/mysteriumnetwork/node/ci/secrets"
 	"github.com/mysteriumnetwork/node/logconfig"
 )
 
@@ -31,20 +32,51 @@ type releaseDebianOpts struct {
 	repository  string
 	version     string
 	buildNumber string
+	signer      secrets.Signer
+	options     ReleaseOptions
 }
 
+// signerSetter is implemented by drivers that need the package signing key
+// - currently just ppaDriver. Plugin drivers never see it: signing material
+// must never leave the process that resolved it from Vault/KMS.
+type signerSetter interface {
+	SetSigner(secrets.Signer)
+}
+
+// optionsSetter is implemented by drivers whose upload behavior (codename
+// concurrency, hedging, retries, dry-run) is CI-tunable - currently just
+// ppaDriver.
+type optionsSetter interface {
+	SetOptions(ReleaseOptions)
+}
+
+// releaseDebianPPA resolves the "ppa" release driver and invokes it with
+// opts converted into a releasepb.ReleaseRequest. Target-specific logic now
+// lives in the registered driver (see ppa_driver.go); this function is just
+// the Prepare+Publish sequence every release target goes through.
 func releaseDebianPPA(opts *releaseDebianOpts) error {
-	if err := deb.TermsTemplateFile("debian/templates"); err != nil {
+	driver, err := Driver("ppa")
+	if err != nil {
 		return err
 	}
+	if setter, ok := driver.(signerSetter); ok {
+		setter.SetSigner(opts.signer)
+	}
+	if setter, ok := driver.(optionsSetter); ok {
+		setter.SetOptions(opts.options)
+	}
+
+	req := &releasepb.ReleaseRequest{
+		Target:      "ppa",
+		Repository:  opts.repository,
+		Version:     opts.version,
+		BuildNumber: opts.buildNumber,
+	}
 
-	for _, codename := range []string{"bionic", "focal", "jammy", "lunar"} {
-		err := shell.NewCmdf("bin/release_ppa %s %s %s %s", opts.repository, opts.version, opts.buildNumber, codename).Run()
-		if err != nil {
-			return err
-		}
+	if err := driver.Prepare(req); err != nil {
+		return err
 	}
-	return nil
+	return driver.Publish(req)
 }
 
 func ppaVersion(buildVersion string) string {
@@ -61,8 +93,10 @@ func ppaVersion(buildVersion string) string {
 	return ver
 }
 
-// ReleaseDebianPPASnapshot releases to node-dev PPA
-func ReleaseDebianPPASnapshot() error {
+// ReleaseDebianPPASnapshot releases to node-dev PPA. An optional
+// ReleaseOptions tunes upload concurrency, hedging, retries and dry-run;
+// DefaultReleaseOptions() applies when none is given.
+func ReleaseDebianPPASnapshot(options ...ReleaseOptions) error {
 	logconfig.Bootstrap()
 
 	if err := env.EnsureEnvVars(
@@ -76,15 +110,25 @@ func ReleaseDebianPPASnapshot() error {
 		return env.Bool(env.SnapshotBuild)
 	})
 
+	signer, err := secrets.DefaultSigner("transit", "ppa-signing-key", os.Getenv("PPA_SIGNING_KMS_KEY_ID"), "RSASSA_PKCS1_V1_5_SHA_256")
+	if err != nil {
+		return err
+	}
+
 	return releaseDebianPPA(&releaseDebianOpts{
 		repository:  "node-dev",
 		version:     ppaVersion(env.Str(env.BuildVersion)),
 		buildNumber: env.Str(env.BuildNumber),
+		signer:      signer,
+		options:     releaseOptionsOrDefault(options),
 	})
 }
 
-// ReleaseDebianPPAPreRelease releases to node-pre PPA (which is then manually promoted to node PPA)
-func ReleaseDebianPPAPreRelease() error {
+// ReleaseDebianPPAPreRelease releases to node-pre PPA (which is then
+// manually promoted to node PPA). An optional ReleaseOptions tunes upload
+// concurrency, hedging, retries and dry-run; DefaultReleaseOptions() applies
+// when none is given.
+func ReleaseDebianPPAPreRelease(options ...ReleaseOptions) error {
 	logconfig.Bootstrap()
 
 	if err := env.EnsureEnvVars(
@@ -99,9 +143,23 @@ func ReleaseDebianPPAPreRelease() error {
 		return env.Bool(env.TagBuild) && !env.Bool(env.RCBuild)
 	})
 
+	signer, err := secrets.DefaultSigner("transit", "ppa-signing-key", os.Getenv("PPA_SIGNING_KMS_KEY_ID"), "RSASSA_PKCS1_V1_5_SHA_256")
+	if err != nil {
+		return err
+	}
+
 	return releaseDebianPPA(&releaseDebianOpts{
 		repository:  "node-pre",
 		version:     ppaVersion(env.Str(env.BuildVersion)),
 		buildNumber: env.Str(env.BuildNumber),
+		signer:      signer,
+		options:     releaseOptionsOrDefault(options),
 	})
 }
+
+func releaseOptionsOrDefault(options []ReleaseOptions) ReleaseOptions {
+	if len(options) == 0 {
+		return DefaultReleaseOptions()
+	}
+	return options[0]
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mysteriumnetwork/node/ci/release/releasepb"
+)
+
+// ReleaseDriver is implemented by every release target (PPA, snap, docker,
+// github-release, homebrew-tap, ...). A driver may run in-process or be a
+// thin client talking to an out-of-process plugin binary over gRPC - callers
+// don't need to know which.
+type ReleaseDriver interface {
+	// Prepare builds or stages whatever artifacts the target needs before
+	// publishing (e.g. signing, packaging, rendering templates).
+	Prepare(req *releasepb.ReleaseRequest) error
+	// Publish pushes the prepared artifacts to the target.
+	Publish(req *releasepb.ReleaseRequest) error
+	// Promote moves an already published release forward, e.g. from a
+	// pre-release channel to the stable one.
+	Promote(req *releasepb.ReleaseRequest) error
+	// Rollback undoes the effects of a previous Publish/Promote call.
+	Rollback(req *releasepb.ReleaseRequest) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]ReleaseDriver)
+)
+
+// RegisterDriver registers a ReleaseDriver under the given target type
+// ("ppa", "snap", "docker", "github-release", "homebrew-tap", ...). It is
+// meant to be called from package init() functions.
+func RegisterDriver(target string, driver ReleaseDriver) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[target] = driver
+}
+
+// Driver resolves the ReleaseDriver registered for the given target type.
+func Driver(target string) (ReleaseDriver, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	driver, ok := registry[target]
+	if !ok {
+		return nil, fmt.Errorf("no release driver registered for target %q", target)
+	}
+	return driver, nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// attempt runs a single upload, either against the primary endpoint or,
+// when hedge is true, against the mirror.
+type attempt func(ctx context.Context, hedge bool) error
+
+// hedged runs primary, and if it hasn't completed within delay, also starts
+// a second attempt against the mirror endpoint - inspired by
+// cristalhq/hedgedhttp. Whichever attempt succeeds first wins, regardless of
+// which one that is; an error is only returned once every attempt that was
+// started has failed.
+func hedged(ctx context.Context, delay time.Duration, run attempt) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	result := make(chan error, 2)
+	go func() { result <- run(ctx, false) }()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	pending := 1
+	secondaryStarted := false
+	var lastErr error
+
+	for {
+		select {
+		case err := <-result:
+			pending--
+			if err == nil {
+				return nil
+			}
+			lastErr = err
+			if pending == 0 {
+				return lastErr
+			}
+			// The other attempt is still in flight - wait for it instead of
+			// failing fast on the first error.
+		case <-timer.C:
+			if !secondaryStarted {
+				secondaryStarted = true
+				pending++
+				go func() { result <- run(ctx, true) }()
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// isTransient reports whether err is worth retrying: network errors and
+// Launchpad 5xx responses are, malformed input or auth failures aren't.
+func isTransient(err error) bool {
+	// bin/release_ppa has no structured exit codes today, so we retry
+	// everything except context cancellation/deadline - the caller's
+	// MaxRetries bound keeps this safe.
+	return err != nil && err != context.Canceled && err != context.DeadlineExceeded
+}
+
+// backoffWithJitter runs fn up to maxRetries+1 times, waiting
+// min(2^attempt * 500ms, 30s) plus up to 50% jitter between attempts, and
+// stops early once fn succeeds or returns a non-transient error.
+func backoffWithJitter(ctx context.Context, maxRetries int, fn func() error) error {
+	const (
+		baseDelay = 500 * time.Millisecond
+		maxDelay  = 30 * time.Second
+	)
+
+	var err error
+	for i := 0; i <= maxRetries; i++ {
+		err = fn()
+		if err == nil || !isTransient(err) {
+			return err
+		}
+		if i == maxRetries {
+			break
+		}
+
+		delay := baseDelay * time.Duration(1<<uint(i))
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		delay += time.Duration(rand.Int63n(int64(delay) / 2))
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedgedPrimarySuccessNeverStartsSecondary(t *testing.T) {
+	var secondaryCalls int32
+	err := hedged(context.Background(), 10*time.Millisecond, func(ctx context.Context, hedge bool) error {
+		if hedge {
+			atomic.AddInt32(&secondaryCalls, 1)
+			return nil
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, atomic.LoadInt32(&secondaryCalls))
+}
+
+func TestHedgedSecondarySucceedsEvenIfPrimaryFailsFirst(t *testing.T) {
+	err := hedged(context.Background(), time.Millisecond, func(ctx context.Context, hedge bool) error {
+		if hedge {
+			return nil
+		}
+		// Primary is slower than the hedge delay and eventually fails -
+		// the hedged secondary should still win.
+		time.Sleep(20 * time.Millisecond)
+		return errors.New("primary failed")
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestHedgedPrimarySucceedsAfterHedgeFired(t *testing.T) {
+	// Regression test: a naive implementation that does `return <-result`
+	// right after starting the secondary can pick up whichever result
+	// channel send happens to be read first, even if it's a later failure
+	// from the secondary landing after primary already succeeded via a
+	// buffered channel read ordering. First success must always win.
+	err := hedged(context.Background(), time.Millisecond, func(ctx context.Context, hedge bool) error {
+		if hedge {
+			time.Sleep(20 * time.Millisecond)
+			return errors.New("secondary failed")
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestHedgedReturnsErrorOnlyWhenBothFail(t *testing.T) {
+	err := hedged(context.Background(), time.Millisecond, func(ctx context.Context, hedge bool) error {
+		if hedge {
+			return errors.New("secondary failed")
+		}
+		time.Sleep(10 * time.Millisecond)
+		return errors.New("primary failed")
+	})
+
+	assert.Error(t, err)
+}
+
+func TestHedgedNoHedgeWhenPrimaryFailsBeforeDelay(t *testing.T) {
+	var secondaryCalls int32
+	err := hedged(context.Background(), 50*time.Millisecond, func(ctx context.Context, hedge bool) error {
+		if hedge {
+			atomic.AddInt32(&secondaryCalls, 1)
+			return nil
+		}
+		return errors.New("primary failed fast")
+	})
+
+	assert.Error(t, err)
+	assert.EqualValues(t, 0, atomic.LoadInt32(&secondaryCalls))
+}
+
+func TestBackoffWithJitterStopsOnSuccess(t *testing.T) {
+	calls := 0
+	err := backoffWithJitter(context.Background(), 5, func() error {
+		calls++
+		if calls == 2 {
+			return nil
+		}
+		return errors.New("transient")
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestBackoffWithJitterStopsOnNonTransientError(t *testing.T) {
+	calls := 0
+	wantErr := context.Canceled
+	err := backoffWithJitter(context.Background(), 5, func() error {
+		calls++
+		return wantErr
+	})
+
+	assert.Equal(t, wantErr, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestBackoffWithJitterExhaustsMaxRetries(t *testing.T) {
+	calls := 0
+	err := backoffWithJitter(context.Background(), 3, func() error {
+		calls++
+		return errors.New("always transient")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 4, calls) // initial attempt + 3 retries
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.False(t, isTransient(nil))
+	assert.False(t, isTransient(context.Canceled))
+	assert.False(t, isTransient(context.DeadlineExceeded))
+	assert.True(t, isTransient(errors.New("connection reset")))
+}
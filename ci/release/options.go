@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import "time"
+
+// ReleaseOptions tunes how a release driver uploads its targets: how many
+// run concurrently, how eagerly a hedge request fires against a mirror, how
+// many times a transient failure is retried, and which targets to build at
+// all. CI pipelines construct this once per environment so behavior (e.g.
+// concurrency) can differ between, say, a laptop dry-run and the release
+// worker.
+type ReleaseOptions struct {
+	// Concurrency caps how many codename uploads run at once.
+	Concurrency int
+	// HedgeDelay is how long a primary upload is given to show progress
+	// before a secondary attempt is launched against the mirror endpoint.
+	HedgeDelay time.Duration
+	// MaxRetries bounds how many times a transient failure (network error,
+	// Launchpad 5xx) is retried with exponential backoff before giving up.
+	MaxRetries int
+	// Codenames lists the Ubuntu codenames to upload to. Defaults to
+	// DefaultCodenames.
+	Codenames []string
+	// DryRun, when true, prints the planned dput invocations instead of
+	// running them.
+	DryRun bool
+}
+
+// DefaultCodenames is the set of Ubuntu codenames released to when
+// ReleaseOptions.Codenames is left empty.
+var DefaultCodenames = []string{"bionic", "focal", "jammy", "noble"}
+
+// DefaultReleaseOptions returns the options releaseDebianPPA used before
+// ReleaseOptions existed: two codenames uploaded at a time, a 30s hedge
+// delay and up to 3 retries.
+func DefaultReleaseOptions() ReleaseOptions {
+	return ReleaseOptions{
+		Concurrency: 2,
+		HedgeDelay:  30 * time.Second,
+		MaxRetries:  3,
+		Codenames:   DefaultCodenames,
+	}
+}
+
+func (o ReleaseOptions) codenames() []string {
+	if len(o.Codenames) == 0 {
+		return DefaultCodenames
+	}
+	return o.Codenames
+}
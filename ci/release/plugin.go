@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"time"
+
+	"github.com/mysteriumnetwork/node/ci/release/releasepb"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// PluginDriver is a ReleaseDriver backed by a separate binary speaking the
+// releasepb.ReleaseDriver gRPC service. It lets downstream users add custom
+// release targets (private APT repos, internal artifact stores, ...) without
+// forking this repo - similar to how Helm's Rudder decouples orchestration
+// from Tiller.
+type PluginDriver struct {
+	// Path is the plugin binary to launch. The plugin is expected to print
+	// its listen address ("127.0.0.1:PORT") to stdout on its first line and
+	// then serve the ReleaseDriver service.
+	Path string
+	// DialTimeout bounds how long we wait for the plugin to start listening.
+	DialTimeout time.Duration
+}
+
+// NewPluginDriver creates a driver that launches the plugin binary at path
+// on demand for every call.
+func NewPluginDriver(path string) *PluginDriver {
+	return &PluginDriver{Path: path, DialTimeout: 10 * time.Second}
+}
+
+func (d *PluginDriver) Prepare(req *releasepb.ReleaseRequest) error {
+	return d.call(req, func(c releasepb.ReleaseDriverClient, ctx context.Context) (logStream, error) {
+		return c.Prepare(ctx, req)
+	})
+}
+
+func (d *PluginDriver) Publish(req *releasepb.ReleaseRequest) error {
+	return d.call(req, func(c releasepb.ReleaseDriverClient, ctx context.Context) (logStream, error) {
+		return c.Publish(ctx, req)
+	})
+}
+
+func (d *PluginDriver) Promote(req *releasepb.ReleaseRequest) error {
+	return d.call(req, func(c releasepb.ReleaseDriverClient, ctx context.Context) (logStream, error) {
+		return c.Promote(ctx, req)
+	})
+}
+
+func (d *PluginDriver) Rollback(req *releasepb.ReleaseRequest) error {
+	return d.call(req, func(c releasepb.ReleaseDriverClient, ctx context.Context) (logStream, error) {
+		return c.Rollback(ctx, req)
+	})
+}
+
+type logStream interface {
+	Recv() (*releasepb.LogLine, error)
+}
+
+func (d *PluginDriver) call(req *releasepb.ReleaseRequest, invoke func(releasepb.ReleaseDriverClient, context.Context) (logStream, error)) error {
+	addr, cleanup, err := d.launch()
+	if err != nil {
+		return fmt.Errorf("launching plugin %s: %w", d.Path, err)
+	}
+	defer cleanup()
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("dialing plugin %s at %s: %w", d.Path, addr, err)
+	}
+	defer conn.Close()
+
+	client := releasepb.NewReleaseDriverClient(conn)
+	stream, err := invoke(client, context.Background())
+	if err != nil {
+		return err
+	}
+
+	for {
+		line, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if line.Error {
+			return fmt.Errorf("plugin %s reported error (%s): %s", d.Path, line.Code, line.Text)
+		}
+		log.Info().Str("plugin", d.Path).Msg(line.Text)
+	}
+}
+
+// launch starts the plugin binary and waits for it to report the address it
+// is listening on.
+func (d *PluginDriver) launch() (addr string, cleanup func(), err error) {
+	cmd := exec.Command(d.Path)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return "", nil, err
+	}
+
+	addrCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		var line string
+		if _, err := fmt.Fscanln(stdout, &line); err != nil {
+			errCh <- err
+			return
+		}
+		addrCh <- line
+	}()
+
+	select {
+	case addr = <-addrCh:
+	case err = <-errCh:
+	case <-time.After(d.DialTimeout):
+		err = fmt.Errorf("timed out waiting for plugin %s to start", d.Path)
+	}
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return "", nil, err
+	}
+
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		_ = cmd.Process.Kill()
+		return "", nil, fmt.Errorf("plugin %s printed invalid address %q: %w", d.Path, addr, err)
+	}
+
+	return addr, func() { _ = cmd.Process.Kill() }, nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mysteriumnetwork/go-ci/shell"
+	"github.com/mysteriumnetwork/node/ci/deb"
+	"github.com/mysteriumnetwork/node/ci/release/releasepb"
+	"github.com/mysteriumnetwork/node/ci/secrets"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+func init() {
+	RegisterDriver("ppa", &ppaDriver{options: DefaultReleaseOptions()})
+}
+
+// ppaDriver is the in-process ReleaseDriver implementation for Launchpad PPA
+// uploads. It is the default "ppa" target and the one every other driver in
+// this package is modelled after.
+type ppaDriver struct {
+	signer  secrets.Signer
+	options ReleaseOptions
+}
+
+// SetSigner configures the Signer used to sign .changes files before
+// upload. Without one, Prepare skips signing (e.g. for --dry-run builds).
+func (d *ppaDriver) SetSigner(signer secrets.Signer) {
+	d.signer = signer
+}
+
+// SetOptions configures concurrency, hedging, retries and dry-run for
+// Publish. Without a call to SetOptions, DefaultReleaseOptions() applies.
+func (d *ppaDriver) SetOptions(options ReleaseOptions) {
+	d.options = options
+}
+
+// Prepare renders the debian changelog/control templates used by every
+// codename upload and, if a Signer is configured, signs the resulting
+// .changes file in place of the old debsign shell-out - the signing key
+// itself never has to sit on the CI worker.
+func (d *ppaDriver) Prepare(req *releasepb.ReleaseRequest) error {
+	if err := deb.TermsTemplateFile("debian/templates"); err != nil {
+		return err
+	}
+	if d.signer == nil {
+		return nil
+	}
+	return d.signChanges()
+}
+
+// signChanges is an in-process dput/debsign equivalent: it reads every
+// rendered .changes file, signs it via the configured Signer and writes the
+// detached signature alongside it as <file>.asc.
+func (d *ppaDriver) signChanges() error {
+	matches, err := filepath.Glob("debian/output/*.changes")
+	if err != nil {
+		return fmt.Errorf("globbing .changes files: %w", err)
+	}
+
+	for _, changesFile := range matches {
+		payload, err := os.ReadFile(changesFile)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", changesFile, err)
+		}
+
+		signature, err := d.signer.Sign(payload)
+		if err != nil {
+			return fmt.Errorf("signing %s: %w", changesFile, err)
+		}
+
+		if err := os.WriteFile(changesFile+".asc", signature, 0o644); err != nil {
+			return fmt.Errorf("writing signature for %s: %w", changesFile, err)
+		}
+	}
+	return nil
+}
+
+// Publish uploads the prepared package to the PPA for every codename in
+// d.options concurrently (bounded by options.Concurrency). Each upload is
+// hedged against a mirror endpoint and retried with exponential backoff on
+// transient failures; --dry-run prints the planned dput invocations instead
+// of running them.
+func (d *ppaDriver) Publish(req *releasepb.ReleaseRequest) error {
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(d.options.Concurrency)
+
+	for _, codename := range d.options.codenames() {
+		codename := codename
+		group.Go(func() error {
+			return backoffWithJitter(ctx, d.options.MaxRetries, func() error {
+				return hedged(ctx, d.options.HedgeDelay, func(ctx context.Context, hedge bool) error {
+					return d.uploadCodename(ctx, req, codename, hedge)
+				})
+			})
+		})
+	}
+
+	return group.Wait()
+}
+
+// uploadCodename runs (or, in dry-run mode, prints) a single dput invocation
+// for codename. hedge selects the mirror Launchpad endpoint.
+func (d *ppaDriver) uploadCodename(ctx context.Context, req *releasepb.ReleaseRequest, codename string, hedge bool) error {
+	endpoint := "ppa.launchpad.net"
+	if hedge {
+		endpoint = "ppa-mirror.launchpad.net"
+	}
+
+	cmdLine := fmt.Sprintf("bin/release_ppa %s %s %s %s --endpoint=%s", req.Repository, req.Version, req.BuildNumber, codename, endpoint)
+
+	if d.options.DryRun {
+		log.Info().Str("codename", codename).Msgf("dry-run: would run %q", cmdLine)
+		return nil
+	}
+
+	return shell.NewCmdf(cmdLine).Run()
+}
+
+// Promote is not supported by the ppa driver - node-pre to node promotion is
+// still a manual Launchpad operation.
+func (d *ppaDriver) Promote(req *releasepb.ReleaseRequest) error {
+	return fmt.Errorf("ppa driver does not support Promote")
+}
+
+// Rollback is not supported by the ppa driver - Launchpad has no API to
+// unpublish an accepted upload.
+func (d *ppaDriver) Rollback(req *releasepb.ReleaseRequest) error {
+	return fmt.Errorf("ppa driver does not support Rollback")
+}
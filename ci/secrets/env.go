@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// EnvSigner signs locally with a GPG private key read from an environment
+// variable. It is the local-dev fallback for when there's no Vault or KMS
+// available - never use it on a CI worker that publishes real releases.
+type EnvSigner struct {
+	privateKeyEnvVar string
+}
+
+// NewEnvSigner creates a signer that reads an armored GPG private key from
+// envVar.
+func NewEnvSigner(envVar string) *EnvSigner {
+	return &EnvSigner{privateKeyEnvVar: envVar}
+}
+
+// Sign produces a detached PGP signature for payload using the key in
+// s.privateKeyEnvVar.
+func (s *EnvSigner) Sign(payload []byte) ([]byte, error) {
+	armored := os.Getenv(s.privateKeyEnvVar)
+	if armored == "" {
+		return nil, fmt.Errorf("env signer: %s is not set", s.privateKeyEnvVar)
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader([]byte(armored)))
+	if err != nil {
+		return nil, fmt.Errorf("reading gpg key from %s: %w", s.privateKeyEnvVar, err)
+	}
+	if len(keyring) == 0 {
+		return nil, fmt.Errorf("env signer: %s contains no keys", s.privateKeyEnvVar)
+	}
+
+	var signature bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&signature, keyring[0], bytes.NewReader(payload), nil); err != nil {
+		return nil, fmt.Errorf("signing payload: %w", err)
+	}
+	return signature.Bytes(), nil
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package secrets
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// DefaultSigner picks a Signer based on which CI environment variables are
+// present: Vault Transit first, then AWS KMS, falling back to the local-dev
+// env var signer so a contributor without access to either can still build.
+func DefaultSigner(vaultTransitMount, vaultKeyName, kmsKeyID, kmsAlgorithm string) (Signer, error) {
+	if vaultToken := os.Getenv("VAULT_TOKEN"); os.Getenv("VAULT_ADDR") != "" && vaultToken != "" {
+		client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+		if err != nil {
+			return nil, fmt.Errorf("creating vault client: %w", err)
+		}
+		// vaultapi.NewClient only reads VAULT_ADDR (and other VAULT_* TLS
+		// settings) from the environment - VAULT_TOKEN is CLI-only behavior,
+		// so it has to be set on the client explicitly or every request goes
+		// out unauthenticated.
+		client.SetToken(vaultToken)
+		return NewVaultSigner(client, vaultTransitMount, vaultKeyName), nil
+	}
+
+	if kmsKeyID != "" {
+		sess, err := session.NewSession()
+		if err != nil {
+			return nil, fmt.Errorf("creating aws session: %w", err)
+		}
+		return NewKMSSigner(kms.New(sess), kmsKeyID, kmsAlgorithm), nil
+	}
+
+	return NewEnvSigner("GPG_SIGNING_KEY"), nil
+}
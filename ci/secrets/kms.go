@@ -0,0 +1,93 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package secrets
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// KMSSigner signs payloads through AWS KMS asymmetric keys, as an
+// alternative to VaultSigner for teams already standardized on AWS.
+type KMSSigner struct {
+	client    *kms.KMS
+	keyID     string
+	algorithm string
+}
+
+// NewKMSSigner creates a signer using keyID (a KMS key ID or ARN) with the
+// given signing algorithm, e.g. kms.SigningAlgorithmSpecRsassaPkcs1V15Sha256.
+func NewKMSSigner(client *kms.KMS, keyID, algorithm string) *KMSSigner {
+	return &KMSSigner{client: client, keyID: keyID, algorithm: algorithm}
+}
+
+// Sign wraps kms:Sign in a real OpenPGP detached signature (see
+// signWithOpenPGP) - debsign/dput/Launchpad only ever accept an actual
+// OpenPGP packet, not a raw KMS signature blob. The signing key material
+// never leaves KMS.
+func (s *KMSSigner) Sign(payload []byte) ([]byte, error) {
+	public, err := s.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	return signWithOpenPGP(public, time.Now(), s.signDigest, payload)
+}
+
+// signDigest calls kms:Sign on an already-computed digest (MessageType
+// DIGEST) rather than letting KMS hash the message itself. openpgp computes
+// the digest - over the payload plus the signature's own hashed subpackets -
+// so KMS must sign exactly that digest.
+func (s *KMSSigner) signDigest(digest []byte) ([]byte, error) {
+	out, err := s.client.Sign(&kms.SignInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          digest,
+		MessageType:      aws.String(kms.MessageTypeDigest),
+		SigningAlgorithm: aws.String(s.algorithm),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing with kms key %s: %w", s.keyID, err)
+	}
+	return out.Signature, nil
+}
+
+// publicKey calls kms:GetPublicKey so the key can be embedded in the
+// OpenPGP signature packet (see signWithOpenPGP) - OpenPGP signatures carry
+// their own public key material, unlike KMS's raw signatures.
+func (s *KMSSigner) publicKey() (*rsa.PublicKey, error) {
+	out, err := s.client.GetPublicKey(&kms.GetPublicKeyInput{
+		KeyId: aws.String(s.keyID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching public key for kms key %s: %w", s.keyID, err)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(out.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kms key %s public key: %w", s.keyID, err)
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("kms key %s is not an RSA key", s.keyID)
+	}
+	return rsaPub, nil
+}
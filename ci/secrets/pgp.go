@@ -0,0 +1,65 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package secrets
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+// remoteSigner adapts a digest-signing backend (Vault Transit, AWS KMS) to
+// crypto.Signer so it can back an OpenPGP packet.PrivateKey. openpgp hashes
+// the payload (plus the signature's own hashed subpackets) itself and only
+// ever hands us the final digest to sign - the private key material never
+// has to leave Vault/KMS to produce a real OpenPGP signature.
+type remoteSigner struct {
+	public crypto.PublicKey
+	sign   func(digest []byte) ([]byte, error)
+}
+
+func (s *remoteSigner) Public() crypto.PublicKey { return s.public }
+
+func (s *remoteSigner) Sign(_ io.Reader, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return s.sign(digest)
+}
+
+// signWithOpenPGP produces a detached, armored OpenPGP signature for
+// payload from an external digest signer - the same output shape dput and
+// Launchpad expect from a real `debsign`/`gpg` invocation, just without the
+// signing key ever touching the CI worker.
+func signWithOpenPGP(public *rsa.PublicKey, created time.Time, sign func(digest []byte) ([]byte, error), payload []byte) ([]byte, error) {
+	priv := packet.NewSignerPrivateKey(created, &remoteSigner{public: public, sign: sign})
+
+	entity := &openpgp.Entity{
+		PrimaryKey: &priv.PublicKey,
+		PrivateKey: priv,
+	}
+
+	var signature bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&signature, entity, bytes.NewReader(payload), nil); err != nil {
+		return nil, fmt.Errorf("signing payload with openpgp: %w", err)
+	}
+	return signature.Bytes(), nil
+}
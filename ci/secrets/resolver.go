@@ -0,0 +1,36 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package secrets resolves and uses CI build secrets (signing keys, tokens)
+// without ever writing them to disk on the CI worker. SecretResolver fetches
+// raw secret material; Signer goes one step further and signs on the
+// resolver's behalf so the key itself never leaves Vault/KMS.
+package secrets
+
+import "context"
+
+// SecretResolver fetches a secret stored at path. path is resolver-specific:
+// a Vault KV path, a KMS key ARN, an env var name, etc.
+type SecretResolver interface {
+	Resolve(ctx context.Context, path string) ([]byte, error)
+}
+
+// Signer signs payload and returns the detached signature, without the
+// signing key ever leaving the backend that implements it.
+type Signer interface {
+	Sign(payload []byte) ([]byte, error)
+}
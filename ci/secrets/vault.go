@@ -0,0 +1,175 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package secrets
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// VaultResolver fetches secrets from Vault's KV v2 engine.
+type VaultResolver struct {
+	client *vaultapi.Client
+	mount  string
+}
+
+// NewVaultResolver creates a resolver against the KV v2 engine mounted at
+// mount (commonly "secret").
+func NewVaultResolver(client *vaultapi.Client, mount string) *VaultResolver {
+	return &VaultResolver{client: client, mount: mount}
+}
+
+// Resolve reads path from the KV v2 engine and returns the "value" field.
+func (r *VaultResolver) Resolve(ctx context.Context, path string) ([]byte, error) {
+	secret, err := r.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/data/%s", r.mount, path))
+	if err != nil {
+		return nil, fmt.Errorf("reading vault secret %s: %w", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("vault secret %s not found", path)
+	}
+
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("vault secret %s has unexpected shape", path)
+	}
+	value, ok := data["value"].(string)
+	if !ok {
+		return nil, fmt.Errorf("vault secret %s has no string \"value\" field", path)
+	}
+	return []byte(value), nil
+}
+
+// VaultSigner signs payloads through Vault's Transit engine, so the GPG
+// signing key used to release Debian packages never leaves Vault.
+type VaultSigner struct {
+	client  *vaultapi.Client
+	mount   string
+	keyName string
+}
+
+// NewVaultSigner creates a signer against the Transit engine mounted at
+// mount, using keyName as the signing key.
+func NewVaultSigner(client *vaultapi.Client, mount, keyName string) *VaultSigner {
+	return &VaultSigner{client: client, mount: mount, keyName: keyName}
+}
+
+// Sign wraps Transit's sign endpoint in a real OpenPGP detached signature
+// (see signWithOpenPGP) - debsign/dput/Launchpad only ever accept an actual
+// OpenPGP packet, not a raw PKCS#1v15 signature blob.
+func (s *VaultSigner) Sign(payload []byte) ([]byte, error) {
+	public, err := s.publicKey()
+	if err != nil {
+		return nil, err
+	}
+	return signWithOpenPGP(public, time.Now(), s.signDigest, payload)
+}
+
+// signDigest calls Transit's sign endpoint on an already-computed digest
+// (prehashed=true) and returns the raw signature bytes. openpgp computes the
+// digest itself - over the payload plus the signature's own hashed
+// subpackets - so Transit must sign exactly that digest rather than
+// re-hashing the payload on its own.
+func (s *VaultSigner) signDigest(digest []byte) ([]byte, error) {
+	secret, err := s.client.Logical().Write(fmt.Sprintf("%s/sign/%s", s.mount, s.keyName), map[string]interface{}{
+		"input":          base64.StdEncoding.EncodeToString(digest),
+		"prehashed":      true,
+		"hash_algorithm": "sha2-256",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing with vault transit key %s: %w", s.keyName, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("vault transit key %s returned no signature", s.keyName)
+	}
+
+	raw, ok := secret.Data["signature"].(string)
+	if !ok {
+		return nil, fmt.Errorf("vault transit key %s returned unexpected signature shape", s.keyName)
+	}
+
+	// Transit signatures are prefixed "vault:v<version>:<base64>".
+	parts := strings.SplitN(raw, ":", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("vault transit key %s returned malformed signature %q", s.keyName, raw)
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+// publicKey reads the Transit key's current public key so it can be embedded
+// in the OpenPGP signature packet (see signWithOpenPGP) - OpenPGP signatures
+// carry their own public key material, unlike Transit's raw signatures.
+func (s *VaultSigner) publicKey() (*rsa.PublicKey, error) {
+	secret, err := s.client.Logical().Read(fmt.Sprintf("%s/keys/%s", s.mount, s.keyName))
+	if err != nil {
+		return nil, fmt.Errorf("reading vault transit key %s: %w", s.keyName, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("vault transit key %s not found", s.keyName)
+	}
+
+	keys, ok := secret.Data["keys"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("vault transit key %s has unexpected shape", s.keyName)
+	}
+
+	latestVersion, ok := secret.Data["latest_version"].(int)
+	if !ok {
+		if n, ok := secret.Data["latest_version"].(string); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil {
+				return nil, fmt.Errorf("vault transit key %s has non-numeric latest_version %q", s.keyName, n)
+			}
+			latestVersion = v
+		} else {
+			return nil, fmt.Errorf("vault transit key %s has no latest_version", s.keyName)
+		}
+	}
+
+	version, ok := keys[strconv.Itoa(latestVersion)].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("vault transit key %s has no version %d", s.keyName, latestVersion)
+	}
+	pemKey, ok := version["public_key"].(string)
+	if !ok {
+		return nil, fmt.Errorf("vault transit key %s version %d has no public_key", s.keyName, latestVersion)
+	}
+
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("vault transit key %s returned an unparseable public key", s.keyName)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing vault transit key %s public key: %w", s.keyName, err)
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("vault transit key %s is not an RSA key", s.keyName)
+	}
+	return rsaPub, nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package config
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// FlagSessionHistoryBackend selects the session history storage engine.
+	FlagSessionHistoryBackend = cli.StringFlag{
+		Name:  "session-history.backend",
+		Usage: "Session history storage backend: embedded, sql or clickhouse",
+		Value: "embedded",
+	}
+	// FlagSessionHistoryDSN is the connection string for the sql and
+	// clickhouse backends.
+	FlagSessionHistoryDSN = cli.StringFlag{
+		Name:  "session-history.dsn",
+		Usage: "Session history storage DSN (ignored for the embedded backend)",
+	}
+	// FlagSessionHistorySQLDriver is the database/sql driver name used by
+	// the sql backend.
+	FlagSessionHistorySQLDriver = cli.StringFlag{
+		Name:  "session-history.sql-driver",
+		Usage: "database/sql driver name used by the sql backend",
+		Value: "postgres",
+	}
+	// FlagSessionHistoryBatchSize bounds how many rows the clickhouse
+	// backend buffers before flushing.
+	FlagSessionHistoryBatchSize = cli.IntFlag{
+		Name:  "session-history.batch-size",
+		Usage: "Number of rows the clickhouse backend buffers before flushing",
+		Value: 500,
+	}
+	// FlagSessionHistoryFlushInterval bounds how long the clickhouse
+	// backend buffers rows before flushing.
+	FlagSessionHistoryFlushInterval = cli.DurationFlag{
+		Name:  "session-history.flush-interval",
+		Usage: "Maximum time the clickhouse backend buffers rows before flushing",
+		Value: 5 * time.Second,
+	}
+)
+
+// RegisterFlagsSessionHistory registers the session-history.* flags with the
+// CLI app's flag set, matching the rest of the node's convention of
+// centralizing flag definitions under config rather than in the packages
+// that consume them.
+func RegisterFlagsSessionHistory(flags *[]cli.Flag) {
+	*flags = append(
+		*flags,
+		&FlagSessionHistoryBackend,
+		&FlagSessionHistoryDSN,
+		&FlagSessionHistorySQLDriver,
+		&FlagSessionHistoryBatchSize,
+		&FlagSessionHistoryFlushInterval,
+	)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/mysteriumnetwork/node/core/connection"
 	"github.com/mysteriumnetwork/node/eventbus"
 	"github.com/mysteriumnetwork/node/identity"
+	"github.com/mysteriumnetwork/node/metrics"
 	session_node "github.com/mysteriumnetwork/node/session"
 	session_event "github.com/mysteriumnetwork/node/session/event"
 	pingpong_event "github.com/mysteriumnetwork/node/session/pingpong/event"
@@ -37,7 +38,9 @@ type StatsRetriever interface {
 	GetDataStats() connection.Statistics
 }
 
-// Storer allows us to get all sessions, save and update them
+// Storer allows us to get all sessions, save and update them. It is
+// implemented by any backend in consumer/session/storage (embedded KV, SQL,
+// ClickHouse, ...) - Storage itself is agnostic to where history rows end up.
 type Storer interface {
 	Store(bucket string, object interface{}) error
 	Update(bucket string, object interface{}) error
@@ -46,7 +49,11 @@ type Storer interface {
 
 type timeGetter func() time.Time
 
-type currentSessionStorage interface {
+// CurrentSessionStorage looks up a currently-running session by ID. It is
+// exported (rather than kept package-private) so composition code outside
+// this package - e.g. consumer/session/storage.Bootstrap - can build a
+// Storage without depending on the concrete type that tracks live sessions.
+type CurrentSessionStorage interface {
 	Find(id session_node.ID) (session_node.Session, bool)
 }
 
@@ -54,23 +61,32 @@ type currentSessionStorage interface {
 type Storage struct {
 	storage         Storer
 	timeGetter      timeGetter
-	currentSessions currentSessionStorage
+	currentSessions CurrentSessionStorage
+	metrics         metrics.MetricSink
 
 	mu             sync.RWMutex
 	sessionsActive map[session_node.ID]History
 }
 
 // NewSessionStorage creates session repository with given dependencies
-func NewSessionStorage(storage Storer, currentSessions currentSessionStorage) *Storage {
+func NewSessionStorage(storage Storer, currentSessions CurrentSessionStorage) *Storage {
 	return &Storage{
 		storage:         storage,
 		timeGetter:      time.Now,
 		currentSessions: currentSessions,
+		metrics:         metrics.NoopSink{},
 
 		sessionsActive: make(map[session_node.ID]History),
 	}
 }
 
+// SetMetricSink configures where Storage reports session metrics to. It
+// defaults to a no-op sink, so wiring Prometheus/StatsD/in-memory export is
+// opt-in.
+func (repo *Storage) SetMetricSink(sink metrics.MetricSink) {
+	repo.metrics = sink
+}
+
 // Subscribe subscribes to relevant events of event bus.
 func (repo *Storage) Subscribe(bus eventbus.Subscriber) error {
 	if err := bus.Subscribe(session_event.AppTopicSession, repo.consumeServiceSessionEvent); err != nil {
@@ -166,6 +182,10 @@ func (repo *Storage) consumeConnectionStatisticsEvent(e connection.AppEventConne
 	row.DataSent = e.Stats.BytesSent
 	row.DataReceived = e.Stats.BytesReceived
 	repo.sessionsActive[e.SessionInfo.SessionID] = row
+
+	labels := map[string]string{"service_type": row.ServiceType}
+	repo.metrics.SetGauge("session_bytes_sent", float64(row.DataSent), labels)
+	repo.metrics.SetGauge("session_bytes_received", float64(row.DataReceived), labels)
 }
 
 func (repo *Storage) consumeConnectionSpendingEvent(e pingpong_event.AppEventInvoicePaid) {
@@ -188,6 +208,7 @@ func (repo *Storage) consumeConnectionSpendingEvent(e pingpong_event.AppEventInv
 	}
 
 	repo.sessionsActive[sessionID] = row
+	repo.metrics.IncrCounter("session_tokens_earned", 1, map[string]string{"service_type": row.ServiceType})
 	log.Debug().Msgf("Session %v updated", sessionID)
 }
 
@@ -210,6 +231,10 @@ func (repo *Storage) handleEndedEvent(sessionID session_node.ID) {
 	}
 
 	delete(repo.sessionsActive, sessionID)
+
+	labels := map[string]string{"service_type": row.ServiceType}
+	repo.metrics.IncrCounter("session_active", -1, labels)
+	repo.metrics.AddSample("session_duration_seconds", row.Updated.Sub(row.Started).Seconds(), labels)
 	log.Debug().Msgf("Session %v updated with final data", sessionID)
 }
 
@@ -231,5 +256,6 @@ func (repo *Storage) handleCreatedEvent(sessionID session_node.ID) {
 	}
 
 	repo.sessionsActive[sessionID] = row
+	repo.metrics.IncrCounter("session_active", 1, map[string]string{"service_type": row.ServiceType})
 	log.Debug().Msgf("Session %v saved", row.SessionID)
 }
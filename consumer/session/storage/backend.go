@@ -0,0 +1,31 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package storage provides session history storage backends. session.Storage
+// no longer assumes its history rows land in the embedded bbolt-like store -
+// it depends only on the Backend interface below, which the embedded, SQL and
+// ClickHouse implementations in this package all satisfy.
+package storage
+
+// Backend persists session.History rows for a given storage engine. It has
+// the same shape as session.Storer so any of these implementations can be
+// passed straight into session.NewSessionStorage.
+type Backend interface {
+	Store(bucket string, object interface{}) error
+	Update(bucket string, object interface{}) error
+	GetAllFrom(bucket string, array interface{}) error
+}
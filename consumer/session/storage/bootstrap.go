@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/mysteriumnetwork/node/consumer/session"
+	"github.com/urfave/cli/v2"
+)
+
+// Bootstrap builds the session.Storage backend selected by the parsed
+// --session-history.* flags (config.RegisterFlagsSessionHistory is expected
+// to have already been called against the CLI app's flag set before ctx is
+// parsed), migrating existing rows out of kv if a non-embedded backend was
+// chosen. It is meant to be the single composition point the node's startup
+// code (core/node, alongside the rest of the Dependencies wiring) calls once
+// ctx, kv and currentSessions all exist.
+func Bootstrap(ctx *cli.Context, kv Backend, currentSessions session.CurrentSessionStorage) (*session.Storage, error) {
+	cfg := ParseFlags(ctx)
+
+	backend, err := NewBackend(cfg, kv)
+	if err != nil {
+		return nil, fmt.Errorf("building session history backend: %w", err)
+	}
+
+	if cfg.Backend != "" && cfg.Backend != "embedded" {
+		if err := Migrate(kv, backend); err != nil {
+			return nil, fmt.Errorf("migrating session history to %s backend: %w", cfg.Backend, err)
+		}
+	}
+
+	return session.NewSessionStorage(backend, currentSessions), nil
+}
@@ -0,0 +1,261 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mysteriumnetwork/node/consumer/session"
+	"github.com/mysteriumnetwork/node/identity"
+	"github.com/rs/zerolog/log"
+
+	// Blank-imported so database/sql has a "clickhouse" driver registered -
+	// sql.Open("clickhouse", ...) fails with "unknown driver" otherwise. Its
+	// placeholder syntax is already the ? database/sql uses by default, so
+	// (unlike sql.go's postgres backend) no query rewriting is needed here.
+	_ "github.com/ClickHouse/clickhouse-go"
+)
+
+const clickhouseHistoryTable = `CREATE TABLE IF NOT EXISTS history (
+	session_id       String,
+	direction        String,
+	consumer_id      String,
+	accountant_id    String,
+	provider_id      String,
+	service_type     String,
+	provider_country String,
+	data_sent        UInt64,
+	data_received    UInt64,
+	tokens           String,
+	started          DateTime,
+	updated          DateTime,
+	status           String
+) ENGINE = MergeTree() ORDER BY (started, session_id)`
+
+// ClickHouseBackend is a columnar backend optimized for aggregating
+// bytes/tokens across millions of sessions, for fleets running many
+// concurrent nodes that want cross-node analytics rather than per-node
+// lookups. Writes are batched and flushed asynchronously since ClickHouse
+// is built for bulk inserts, not per-event round trips.
+type ClickHouseBackend struct {
+	db            *sql.DB
+	batchSize     int
+	flushInterval time.Duration
+
+	pending chan clickhouseRow
+	done    chan struct{}
+
+	// closeMu guards pending against a send racing Close's close(pending):
+	// Store/Update hold it for reading while they send, so Close (which
+	// takes it for writing) can't close the channel out from under them.
+	closeMu sync.RWMutex
+	closed  bool
+}
+
+type clickhouseRow struct {
+	row    session.History
+	update bool
+}
+
+// NewClickHouseBackend opens dsn and starts the background batch writer.
+// batchSize and flushInterval bound how long a row can sit unflushed; rows
+// are written whichever comes first.
+func NewClickHouseBackend(dsn string, batchSize int, flushInterval time.Duration) (*ClickHouseBackend, error) {
+	db, err := sql.Open("clickhouse", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening clickhouse database: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("connecting to clickhouse: %w", err)
+	}
+	if _, err := db.Exec(clickhouseHistoryTable); err != nil {
+		return nil, fmt.Errorf("creating clickhouse history table: %w", err)
+	}
+
+	backend := &ClickHouseBackend{
+		db:            db,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		pending:       make(chan clickhouseRow, batchSize*4),
+		done:          make(chan struct{}),
+	}
+	go backend.writeLoop()
+	return backend, nil
+}
+
+// Store implements Backend by queuing the row for the next batch flush.
+func (b *ClickHouseBackend) Store(bucket string, object interface{}) error {
+	row, ok := object.(*session.History)
+	if !ok {
+		return fmt.Errorf("clickhouse backend: unsupported object type %T", object)
+	}
+	return b.enqueue(clickhouseRow{row: *row})
+}
+
+// Update implements Backend. ClickHouse's MergeTree has no efficient
+// row-level update, so an update is represented as another insert of the
+// current state; queries aggregate on the latest "updated" per session_id.
+func (b *ClickHouseBackend) Update(bucket string, object interface{}) error {
+	row, ok := object.(*session.History)
+	if !ok {
+		return fmt.Errorf("clickhouse backend: unsupported object type %T", object)
+	}
+	return b.enqueue(clickhouseRow{row: *row, update: true})
+}
+
+func (b *ClickHouseBackend) enqueue(row clickhouseRow) error {
+	b.closeMu.RLock()
+	defer b.closeMu.RUnlock()
+	if b.closed {
+		return fmt.Errorf("clickhouse backend: closed")
+	}
+	b.pending <- row
+	return nil
+}
+
+// GetAllFrom implements Backend by returning the latest row per session_id.
+func (b *ClickHouseBackend) GetAllFrom(bucket string, array interface{}) error {
+	sessions, ok := array.(*[]session.History)
+	if !ok {
+		return fmt.Errorf("clickhouse backend: unsupported array type %T", array)
+	}
+
+	rows, err := b.db.Query(
+		`SELECT session_id, direction, consumer_id, accountant_id, provider_id,
+			service_type, provider_country, argMax(data_sent, updated),
+			argMax(data_received, updated), argMax(tokens, updated),
+			started, argMax(updated, updated), argMax(status, updated)
+		 FROM history GROUP BY session_id, direction, consumer_id, accountant_id,
+			provider_id, service_type, provider_country, started
+		 ORDER BY started DESC`,
+	)
+	if err != nil {
+		return fmt.Errorf("listing clickhouse history rows: %w", err)
+	}
+	defer rows.Close()
+
+	var result []session.History
+	for rows.Next() {
+		var (
+			row                            session.History
+			consumerID, providerID, tokens string
+		)
+		if err := rows.Scan(
+			&row.SessionID, &row.Direction, &consumerID, &row.AccountantID, &providerID,
+			&row.ServiceType, &row.ProviderCountry, &row.DataSent, &row.DataReceived, &tokens,
+			&row.Started, &row.Updated, &row.Status,
+		); err != nil {
+			return fmt.Errorf("scanning clickhouse history row: %w", err)
+		}
+		row.ConsumerID = identity.FromAddress(consumerID)
+		row.ProviderID = identity.FromAddress(providerID)
+		if _, err := fmt.Sscan(tokens, &row.Tokens); err != nil {
+			return fmt.Errorf("parsing clickhouse history row tokens %q: %w", tokens, err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating clickhouse history rows: %w", err)
+	}
+
+	*sessions = result
+	return nil
+}
+
+// Close flushes any pending rows and stops the background writer.
+func (b *ClickHouseBackend) Close() error {
+	b.closeMu.Lock()
+	b.closed = true
+	close(b.pending)
+	b.closeMu.Unlock()
+
+	<-b.done
+	return b.db.Close()
+}
+
+func (b *ClickHouseBackend) writeLoop() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]clickhouseRow, 0, b.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := b.flush(batch); err != nil {
+			log.Error().Err(err).Int("rows", len(batch)).Msg("Failed to flush session history batch to clickhouse")
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case row, ok := <-b.pending:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, row)
+			if len(batch) >= b.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (b *ClickHouseBackend) flush(batch []clickhouseRow) error {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning clickhouse batch: %w", err)
+	}
+
+	stmt, err := tx.Prepare(
+		`INSERT INTO history (
+			session_id, direction, consumer_id, accountant_id, provider_id,
+			service_type, provider_country, data_sent, data_received, tokens,
+			started, updated, status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("preparing clickhouse batch insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, item := range batch {
+		row := item.row
+		if _, err := stmt.Exec(
+			row.SessionID, row.Direction, fmt.Sprint(row.ConsumerID), row.AccountantID,
+			fmt.Sprint(row.ProviderID), row.ServiceType, row.ProviderCountry,
+			row.DataSent, row.DataReceived, fmt.Sprint(row.Tokens),
+			row.Started, row.Updated, row.Status,
+		); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("batching history row %v: %w", row.SessionID, err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+// EmbeddedBackend wraps the node's embedded KV store so it satisfies
+// Backend. It is the default, zero-config backend and the one every
+// existing installation keeps using after upgrading.
+type EmbeddedBackend struct {
+	kv Backend
+}
+
+// NewEmbeddedBackend adapts an already open embedded KV store (e.g. the
+// node's boltdb-backed storage.Storage) into a Backend.
+func NewEmbeddedBackend(kv Backend) *EmbeddedBackend {
+	return &EmbeddedBackend{kv: kv}
+}
+
+// Store implements Backend.
+func (b *EmbeddedBackend) Store(bucket string, object interface{}) error {
+	return b.kv.Store(bucket, object)
+}
+
+// Update implements Backend.
+func (b *EmbeddedBackend) Update(bucket string, object interface{}) error {
+	return b.kv.Update(bucket, object)
+}
+
+// GetAllFrom implements Backend.
+func (b *EmbeddedBackend) GetAllFrom(bucket string, array interface{}) error {
+	return b.kv.GetAllFrom(bucket, array)
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config configures which session history Backend NewBackend builds.
+type Config struct {
+	// Backend selects the storage engine: "embedded" (default), "sql" or
+	// "clickhouse".
+	Backend string
+	// DSN is the driver-specific connection string for the "sql" and
+	// "clickhouse" backends. Unused for "embedded".
+	DSN string
+	// SQLDriver is the database/sql driver name used by the "sql" backend,
+	// e.g. "postgres" or "mysql".
+	SQLDriver string
+	// BatchSize and FlushInterval bound how long the "clickhouse" backend
+	// buffers rows before writing them out.
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// DefaultConfig is the zero-config embedded-store behavior every
+// installation has today.
+func DefaultConfig() Config {
+	return Config{
+		Backend:       "embedded",
+		SQLDriver:     "postgres",
+		BatchSize:     500,
+		FlushInterval: 5 * time.Second,
+	}
+}
+
+// NewBackend builds the Backend selected by cfg. embedded wraps kv (the
+// node's already open embedded store); kv is ignored for the other backends.
+func NewBackend(cfg Config, kv Backend) (Backend, error) {
+	switch cfg.Backend {
+	case "", "embedded":
+		return NewEmbeddedBackend(kv), nil
+	case "sql":
+		return NewSQLBackend(cfg.SQLDriver, cfg.DSN)
+	case "clickhouse":
+		return NewClickHouseBackend(cfg.DSN, cfg.BatchSize, cfg.FlushInterval)
+	default:
+		return nil, fmt.Errorf("unknown session history backend %q", cfg.Backend)
+	}
+}
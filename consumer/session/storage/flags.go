@@ -0,0 +1,35 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"github.com/mysteriumnetwork/node/config"
+	"github.com/urfave/cli/v2"
+)
+
+// ParseFlags resolves a Config from the session-history.* flags registered
+// by config.RegisterFlagsSessionHistory.
+func ParseFlags(ctx *cli.Context) Config {
+	return Config{
+		Backend:       ctx.String(config.FlagSessionHistoryBackend.Name),
+		DSN:           ctx.String(config.FlagSessionHistoryDSN.Name),
+		SQLDriver:     ctx.String(config.FlagSessionHistorySQLDriver.Name),
+		BatchSize:     ctx.Int(config.FlagSessionHistoryBatchSize.Name),
+		FlushInterval: ctx.Duration(config.FlagSessionHistoryFlushInterval.Name),
+	}
+}
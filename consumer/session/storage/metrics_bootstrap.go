@@ -0,0 +1,43 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/consumer/session"
+	"github.com/mysteriumnetwork/node/metrics"
+	"github.com/mysteriumnetwork/node/tequilapi/endpoints"
+)
+
+// BootstrapMetrics wires repo's session metrics into a Prometheus sink and
+// an in-memory sink queryable over Tequilapi, and registers both sinks'
+// routes on router. It is meant to be called once, alongside Bootstrap, from
+// the node's startup code (core/node) once repo and the Tequilapi router
+// both exist; inMemoryInterval is the bucket size NewInMemorySink groups
+// samples into.
+func BootstrapMetrics(repo *session.Storage, router *httprouter.Router, inMemoryInterval time.Duration) {
+	prom := metrics.NewPrometheusSink()
+	mem := metrics.NewInMemorySink(inMemoryInterval)
+
+	repo.SetMetricSink(metrics.MultiSink{prom, mem})
+
+	endpoints.AddRoutesForPrometheusMetrics(router, prom)
+	endpoints.AddRoutesForSessionMetrics(router, mem)
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/mysteriumnetwork/node/consumer/session"
+	"github.com/rs/zerolog/log"
+)
+
+const historyBucket = "session-history"
+
+// Migrate copies every session history row from an existing backend (e.g.
+// the embedded store) into dst, so switching --session-history.backend
+// doesn't lose history that was already collected.
+func Migrate(src, dst Backend) error {
+	var rows []session.History
+	if err := src.GetAllFrom(historyBucket, &rows); err != nil {
+		return fmt.Errorf("reading source history: %w", err)
+	}
+
+	for i := range rows {
+		if err := dst.Store(historyBucket, &rows[i]); err != nil {
+			return fmt.Errorf("migrating session %v: %w", rows[i].SessionID, err)
+		}
+	}
+
+	log.Info().Int("rows", len(rows)).Msg("Migrated session history")
+	return nil
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mysteriumnetwork/node/consumer/session"
+	"github.com/mysteriumnetwork/node/identity"
+
+	// Blank-imported so database/sql has a "postgres" driver registered -
+	// postgres is DefaultConfig's SQLDriver, and sql.Open fails with
+	// "unknown driver" otherwise.
+	_ "github.com/lib/pq"
+)
+
+// insertHistoryQuery/updateHistoryQuery below come in both a Postgres and a
+// "?"-placeholder form: lib/pq (and Postgres drivers generally) only
+// understand $1, $2, ... placeholders - database/sql's ? shorthand is a
+// MySQL/SQLite-ism that Postgres rejects outright.
+const (
+	insertHistoryQueryPostgres = `INSERT INTO history (
+		session_id, direction, consumer_id, accountant_id, provider_id,
+		service_type, provider_country, data_sent, data_received, tokens,
+		started, updated, status
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	insertHistoryQueryPlaceholder = `INSERT INTO history (
+		session_id, direction, consumer_id, accountant_id, provider_id,
+		service_type, provider_country, data_sent, data_received, tokens,
+		started, updated, status
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	updateHistoryQueryPostgres = `UPDATE history SET data_sent = $1, data_received = $2, tokens = $3, updated = $4, status = $5
+		 WHERE session_id = $6`
+	updateHistoryQueryPlaceholder = `UPDATE history SET data_sent = ?, data_received = ?, tokens = ?, updated = ?, status = ?
+		 WHERE session_id = ?`
+)
+
+// insertHistoryQuery and updateHistoryQuery pick the placeholder dialect
+// matching b.driverName; every driver besides "postgres" (mysql, sqlite3,
+// ...) uses database/sql's ? shorthand.
+func (b *SQLBackend) insertHistoryQuery() string {
+	if b.driverName == "postgres" {
+		return insertHistoryQueryPostgres
+	}
+	return insertHistoryQueryPlaceholder
+}
+
+func (b *SQLBackend) updateHistoryQuery() string {
+	if b.driverName == "postgres" {
+		return updateHistoryQueryPostgres
+	}
+	return updateHistoryQueryPlaceholder
+}
+
+// sqlSchemaMigrations lists the History table migrations in order. They are
+// intentionally additive only - there is no down migration, matching how the
+// rest of the node treats its embedded store.
+var sqlSchemaMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS history (
+		session_id        TEXT PRIMARY KEY,
+		direction         TEXT NOT NULL,
+		consumer_id       TEXT NOT NULL,
+		accountant_id     TEXT NOT NULL,
+		provider_id       TEXT NOT NULL,
+		service_type      TEXT NOT NULL,
+		provider_country  TEXT NOT NULL,
+		data_sent         BIGINT NOT NULL,
+		data_received     BIGINT NOT NULL,
+		tokens            TEXT NOT NULL,
+		started           TIMESTAMP NOT NULL,
+		updated           TIMESTAMP NOT NULL,
+		status            TEXT NOT NULL
+	)`,
+}
+
+// SQLBackend persists session history rows into a relational database
+// through database/sql, so fleets of nodes can share one history store
+// instead of each keeping its own embedded bbolt file.
+type SQLBackend struct {
+	db         *sql.DB
+	driverName string
+}
+
+// NewSQLBackend opens driverName/dsn (e.g. "postgres", "mysql", "sqlite3")
+// and applies the History table migrations.
+func NewSQLBackend(driverName, dsn string) (*SQLBackend, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s database: %w", driverName, err)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("connecting to %s database: %w", driverName, err)
+	}
+
+	backend := &SQLBackend{db: db, driverName: driverName}
+	if err := backend.migrate(); err != nil {
+		return nil, err
+	}
+	return backend, nil
+}
+
+func (b *SQLBackend) migrate() error {
+	for _, stmt := range sqlSchemaMigrations {
+		if _, err := b.db.Exec(stmt); err != nil {
+			return fmt.Errorf("applying history schema migration: %w", err)
+		}
+	}
+	return nil
+}
+
+// Store implements Backend. bucket is kept for interface compatibility with
+// the embedded store; this backend always writes to the history table.
+func (b *SQLBackend) Store(bucket string, object interface{}) error {
+	row, ok := object.(*session.History)
+	if !ok {
+		return fmt.Errorf("sql backend: unsupported object type %T", object)
+	}
+
+	_, err := b.db.Exec(
+		b.insertHistoryQuery(),
+		row.SessionID, row.Direction, fmt.Sprint(row.ConsumerID), row.AccountantID,
+		fmt.Sprint(row.ProviderID), row.ServiceType, row.ProviderCountry,
+		row.DataSent, row.DataReceived, fmt.Sprint(row.Tokens),
+		row.Started, row.Updated, row.Status,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting history row %v: %w", row.SessionID, err)
+	}
+	return nil
+}
+
+// Update implements Backend.
+func (b *SQLBackend) Update(bucket string, object interface{}) error {
+	row, ok := object.(*session.History)
+	if !ok {
+		return fmt.Errorf("sql backend: unsupported object type %T", object)
+	}
+
+	_, err := b.db.Exec(
+		b.updateHistoryQuery(),
+		row.DataSent, row.DataReceived, row.Tokens.String(), row.Updated, row.Status, row.SessionID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating history row %v: %w", row.SessionID, err)
+	}
+	return nil
+}
+
+// GetAllFrom implements Backend.
+func (b *SQLBackend) GetAllFrom(bucket string, array interface{}) error {
+	sessions, ok := array.(*[]session.History)
+	if !ok {
+		return fmt.Errorf("sql backend: unsupported array type %T", array)
+	}
+
+	rows, err := b.db.Query(
+		`SELECT session_id, direction, consumer_id, accountant_id, provider_id,
+			service_type, provider_country, data_sent, data_received, tokens,
+			started, updated, status
+		 FROM history ORDER BY started DESC`,
+	)
+	if err != nil {
+		return fmt.Errorf("listing history rows: %w", err)
+	}
+	defer rows.Close()
+
+	var result []session.History
+	for rows.Next() {
+		var (
+			row                            session.History
+			consumerID, providerID, tokens string
+		)
+		if err := rows.Scan(
+			&row.SessionID, &row.Direction, &consumerID, &row.AccountantID, &providerID,
+			&row.ServiceType, &row.ProviderCountry, &row.DataSent, &row.DataReceived, &tokens,
+			&row.Started, &row.Updated, &row.Status,
+		); err != nil {
+			return fmt.Errorf("scanning history row: %w", err)
+		}
+		row.ConsumerID = identity.FromAddress(consumerID)
+		row.ProviderID = identity.FromAddress(providerID)
+		if _, err := fmt.Sscan(tokens, &row.Tokens); err != nil {
+			return fmt.Errorf("parsing history row tokens %q: %w", tokens, err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating history rows: %w", err)
+	}
+
+	*sessions = result
+	return nil
+}
+
+// Close releases the underlying database connection pool.
+func (b *SQLBackend) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLBackendQueryPlaceholdersPostgres(t *testing.T) {
+	b := &SQLBackend{driverName: "postgres"}
+
+	assert.True(t, strings.Contains(b.insertHistoryQuery(), "$1"))
+	assert.False(t, strings.Contains(b.insertHistoryQuery(), "?"))
+	assert.True(t, strings.Contains(b.updateHistoryQuery(), "$1"))
+	assert.False(t, strings.Contains(b.updateHistoryQuery(), "?"))
+}
+
+func TestSQLBackendQueryPlaceholdersOtherDrivers(t *testing.T) {
+	for _, driver := range []string{"mysql", "sqlite3", ""} {
+		b := &SQLBackend{driverName: driver}
+
+		assert.True(t, strings.Contains(b.insertHistoryQuery(), "?"), driver)
+		assert.False(t, strings.Contains(b.insertHistoryQuery(), "$1"), driver)
+		assert.True(t, strings.Contains(b.updateHistoryQuery(), "?"), driver)
+		assert.False(t, strings.Contains(b.updateHistoryQuery(), "$1"), driver)
+	}
+}
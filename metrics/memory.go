@@ -0,0 +1,232 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// reservoirSize bounds how many samples each interval bucket keeps,
+// regardless of how many AddSample calls land in it - memory stays flat no
+// matter how much session throughput a node sees.
+const reservoirSize = 1000
+
+// Aggregate summarizes one metric over one interval bucket.
+type Aggregate struct {
+	Sum   float64
+	Count int
+	Min   float64
+	Max   float64
+	Mean  float64
+	P50   float64
+	P95   float64
+	P99   float64
+}
+
+type bucket struct {
+	sum, min, max float64
+	count         int
+	seen          int
+	reservoir     []float64
+}
+
+// InMemorySink keeps per-interval aggregates in memory, queryable through
+// the Tequilapi /metrics/sessions endpoint, so operators get observability
+// without having to scrape bbolt or stand up Prometheus.
+type InMemorySink struct {
+	interval time.Duration
+	now      func() time.Time
+	rand     *rand.Rand
+
+	mu      sync.Mutex
+	buckets map[string]map[int64]*bucket
+}
+
+// NewInMemorySink creates a sink that groups samples into buckets of the
+// given interval (e.g. one minute).
+func NewInMemorySink(interval time.Duration) *InMemorySink {
+	return &InMemorySink{
+		interval: interval,
+		now:      time.Now,
+		rand:     rand.New(rand.NewSource(1)),
+		buckets:  make(map[string]map[int64]*bucket),
+	}
+}
+
+// IncrCounter implements MetricSink.
+func (s *InMemorySink) IncrCounter(name string, value float64, labels map[string]string) {
+	s.record(name, value)
+}
+
+// SetGauge implements MetricSink.
+func (s *InMemorySink) SetGauge(name string, value float64, labels map[string]string) {
+	s.record(name, value)
+}
+
+// AddSample implements MetricSink.
+func (s *InMemorySink) AddSample(name string, value float64, labels map[string]string) {
+	s.record(name, value)
+}
+
+func (s *InMemorySink) record(name string, value float64) {
+	key := s.now().Truncate(s.interval).Unix()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byInterval, ok := s.buckets[name]
+	if !ok {
+		byInterval = make(map[int64]*bucket)
+		s.buckets[name] = byInterval
+	}
+
+	b, ok := byInterval[key]
+	if !ok {
+		b = &bucket{min: value, max: value}
+		byInterval[key] = b
+	}
+
+	b.sum += value
+	b.count++
+	if value < b.min {
+		b.min = value
+	}
+	if value > b.max {
+		b.max = value
+	}
+
+	// Reservoir sampling (Algorithm R): keeps a bounded, uniformly random
+	// subset of observed values so percentiles stay accurate without
+	// retaining every sample ever seen.
+	b.seen++
+	switch {
+	case len(b.reservoir) < reservoirSize:
+		b.reservoir = append(b.reservoir, value)
+	default:
+		if j := s.rand.Intn(b.seen); j < reservoirSize {
+			b.reservoir[j] = value
+		}
+	}
+}
+
+// Query returns the aggregate for name over the interval bucket containing
+// at.
+func (s *InMemorySink) Query(name string, at time.Time) (Aggregate, bool) {
+	key := at.Truncate(s.interval).Unix()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byInterval, ok := s.buckets[name]
+	if !ok {
+		return Aggregate{}, false
+	}
+	b, ok := byInterval[key]
+	if !ok {
+		return Aggregate{}, false
+	}
+
+	return aggregateFrom(b), true
+}
+
+// QuerySince returns one aggregate for name merged across every interval
+// bucket at or after since - e.g. a 5m query over a sink with a 1m interval
+// folds five buckets together. Reservoirs are merged before computing
+// percentiles, rather than averaging each bucket's own percentiles, so P50/
+// P95/P99 stay accurate over the whole window.
+func (s *InMemorySink) QuerySince(name string, since time.Time) (Aggregate, bool) {
+	key := since.Truncate(s.interval).Unix()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byInterval, ok := s.buckets[name]
+	if !ok {
+		return Aggregate{}, false
+	}
+
+	merged := &bucket{}
+	found := false
+	for bucketKey, b := range byInterval {
+		if bucketKey < key {
+			continue
+		}
+		if !found {
+			merged.min, merged.max = b.min, b.max
+			found = true
+		} else {
+			if b.min < merged.min {
+				merged.min = b.min
+			}
+			if b.max > merged.max {
+				merged.max = b.max
+			}
+		}
+		merged.sum += b.sum
+		merged.count += b.count
+		merged.reservoir = append(merged.reservoir, b.reservoir...)
+	}
+	if !found {
+		return Aggregate{}, false
+	}
+	return aggregateFrom(merged), true
+}
+
+// QueryRange returns the aggregate for name over every interval bucket
+// starting at or after since, keyed by bucket start time.
+func (s *InMemorySink) QueryRange(name string, since time.Time) map[time.Time]Aggregate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[time.Time]Aggregate)
+	for key, b := range s.buckets[name] {
+		start := time.Unix(key, 0)
+		if start.Before(since) {
+			continue
+		}
+		result[start] = aggregateFrom(b)
+	}
+	return result
+}
+
+func aggregateFrom(b *bucket) Aggregate {
+	sorted := append([]float64(nil), b.reservoir...)
+	sort.Float64s(sorted)
+
+	return Aggregate{
+		Sum:   b.sum,
+		Count: b.count,
+		Min:   b.min,
+		Max:   b.max,
+		Mean:  b.sum / float64(b.count),
+		P50:   percentile(sorted, 0.50),
+		P95:   percentile(sorted, 0.95),
+		P99:   percentile(sorted, 0.99),
+	}
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
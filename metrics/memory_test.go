@@ -0,0 +1,116 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemorySinkAggregatesWithinOneBucket(t *testing.T) {
+	now := time.Unix(1000, 0)
+	sink := NewInMemorySink(time.Minute)
+	sink.now = func() time.Time { return now }
+
+	sink.AddSample("latency", 10, nil)
+	sink.AddSample("latency", 20, nil)
+	sink.AddSample("latency", 30, nil)
+
+	aggregate, ok := sink.Query("latency", now)
+	assert.True(t, ok)
+	assert.Equal(t, 3, aggregate.Count)
+	assert.Equal(t, 60.0, aggregate.Sum)
+	assert.Equal(t, 10.0, aggregate.Min)
+	assert.Equal(t, 30.0, aggregate.Max)
+	assert.Equal(t, 20.0, aggregate.Mean)
+}
+
+func TestInMemorySinkQueryMissingBucket(t *testing.T) {
+	sink := NewInMemorySink(time.Minute)
+	_, ok := sink.Query("unknown", time.Now())
+	assert.False(t, ok)
+}
+
+func TestInMemorySinkPercentiles(t *testing.T) {
+	now := time.Unix(2000, 0)
+	sink := NewInMemorySink(time.Minute)
+	sink.now = func() time.Time { return now }
+
+	for i := 1; i <= 100; i++ {
+		sink.AddSample("duration", float64(i), nil)
+	}
+
+	aggregate, ok := sink.Query("duration", now)
+	assert.True(t, ok)
+	assert.Equal(t, 100, aggregate.Count)
+	// With every one of the 100 samples fitting inside the reservoir
+	// (reservoirSize is 1000), the percentiles are exact.
+	assert.Equal(t, 50.0, aggregate.P50)
+	assert.Equal(t, 95.0, aggregate.P95)
+	assert.Equal(t, 99.0, aggregate.P99)
+}
+
+func TestInMemorySinkQuerySinceMergesBuckets(t *testing.T) {
+	bucketStart := time.Unix(0, 0)
+	sink := NewInMemorySink(time.Minute)
+
+	// Bucket 0: [0m, 1m), bucket 1: [1m, 2m).
+	sink.now = func() time.Time { return bucketStart }
+	sink.AddSample("bytes", 100, nil)
+	sink.now = func() time.Time { return bucketStart.Add(time.Minute) }
+	sink.AddSample("bytes", 300, nil)
+
+	// Querying since the start of bucket 0 should see both buckets merged.
+	aggregate, ok := sink.QuerySince("bytes", bucketStart)
+	assert.True(t, ok)
+	assert.Equal(t, 2, aggregate.Count)
+	assert.Equal(t, 400.0, aggregate.Sum)
+	assert.Equal(t, 100.0, aggregate.Min)
+	assert.Equal(t, 300.0, aggregate.Max)
+
+	// Querying since the start of bucket 1 should only see the second
+	// sample.
+	aggregate, ok = sink.QuerySince("bytes", bucketStart.Add(time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, 1, aggregate.Count)
+	assert.Equal(t, 300.0, aggregate.Sum)
+}
+
+func TestInMemorySinkQuerySinceMissingMetric(t *testing.T) {
+	sink := NewInMemorySink(time.Minute)
+	_, ok := sink.QuerySince("unknown", time.Now())
+	assert.False(t, ok)
+}
+
+func TestMultiSinkFansOutToEverySink(t *testing.T) {
+	a := NewInMemorySink(time.Minute)
+	b := NewInMemorySink(time.Minute)
+	multi := MultiSink{a, b}
+
+	multi.IncrCounter("calls", 1, nil)
+	multi.SetGauge("calls", 5, nil)
+	multi.AddSample("calls", 9, nil)
+
+	for _, sink := range []*InMemorySink{a, b} {
+		aggregate, ok := sink.Query("calls", time.Now())
+		assert.True(t, ok)
+		assert.Equal(t, 3, aggregate.Count)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PrometheusSink exports metrics via the standard /metrics scrape endpoint.
+// It is registered on the Tequilapi HTTP server so node operators don't need
+// a separate port.
+type PrometheusSink struct {
+	registry *prometheus.Registry
+
+	mu       sync.Mutex
+	counters map[string]*prometheus.CounterVec
+	gauges   map[string]*prometheus.GaugeVec
+	samples  map[string]*prometheus.HistogramVec
+}
+
+// NewPrometheusSink creates a sink with its own registry so node metrics
+// don't collide with the default global one.
+func NewPrometheusSink() *PrometheusSink {
+	return &PrometheusSink{
+		registry: prometheus.NewRegistry(),
+		counters: make(map[string]*prometheus.CounterVec),
+		gauges:   make(map[string]*prometheus.GaugeVec),
+		samples:  make(map[string]*prometheus.HistogramVec),
+	}
+}
+
+// Handler returns the http.Handler to register at /metrics.
+func (s *PrometheusSink) Handler() http.Handler {
+	return promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{})
+}
+
+// IncrCounter implements MetricSink.
+func (s *PrometheusSink) IncrCounter(name string, value float64, labels map[string]string) {
+	s.counter(name, labels).With(labels).Add(value)
+}
+
+// SetGauge implements MetricSink.
+func (s *PrometheusSink) SetGauge(name string, value float64, labels map[string]string) {
+	s.gauge(name, labels).With(labels).Set(value)
+}
+
+// AddSample implements MetricSink.
+func (s *PrometheusSink) AddSample(name string, value float64, labels map[string]string) {
+	s.histogram(name, labels).With(labels).Observe(value)
+}
+
+func (s *PrometheusSink) counter(name string, labels map[string]string) *prometheus.CounterVec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counters[name]
+	if !ok {
+		c = prometheus.NewCounterVec(prometheus.CounterOpts{Name: name}, labelNames(labels))
+		s.registry.MustRegister(c)
+		s.counters[name] = c
+	}
+	return c
+}
+
+func (s *PrometheusSink) gauge(name string, labels map[string]string) *prometheus.GaugeVec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.gauges[name]
+	if !ok {
+		g = prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name}, labelNames(labels))
+		s.registry.MustRegister(g)
+		s.gauges[name] = g
+	}
+	return g
+}
+
+func (s *PrometheusSink) histogram(name string, labels map[string]string) *prometheus.HistogramVec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.samples[name]
+	if !ok {
+		h = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name}, labelNames(labels))
+		s.registry.MustRegister(h)
+		s.samples[name] = h
+	}
+	return h
+}
+
+func labelNames(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	return names
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package metrics gives node subsystems a way to emit counters, gauges and
+// samples without depending on a specific observability backend. It is
+// modeled on armon/go-metrics: callers depend only on MetricSink, and any
+// number of sinks can be fanned out to via MultiSink.
+package metrics
+
+// MetricSink receives counter/gauge/sample updates from node subsystems.
+// labels follow the "key=value" convention so sinks that support tags
+// (Prometheus, StatsD) can attach them, while simpler sinks may just ignore
+// them.
+type MetricSink interface {
+	IncrCounter(name string, value float64, labels map[string]string)
+	SetGauge(name string, value float64, labels map[string]string)
+	AddSample(name string, value float64, labels map[string]string)
+}
+
+// MultiSink fans a single metric update out to every sink it wraps, so a
+// node can e.g. export to Prometheus and keep an in-memory sink queryable
+// over Tequilapi at the same time.
+type MultiSink []MetricSink
+
+// IncrCounter implements MetricSink.
+func (m MultiSink) IncrCounter(name string, value float64, labels map[string]string) {
+	for _, sink := range m {
+		sink.IncrCounter(name, value, labels)
+	}
+}
+
+// SetGauge implements MetricSink.
+func (m MultiSink) SetGauge(name string, value float64, labels map[string]string) {
+	for _, sink := range m {
+		sink.SetGauge(name, value, labels)
+	}
+}
+
+// AddSample implements MetricSink.
+func (m MultiSink) AddSample(name string, value float64, labels map[string]string) {
+	for _, sink := range m {
+		sink.AddSample(name, value, labels)
+	}
+}
+
+// NoopSink discards every update. It is the default sink so wiring a real
+// one stays opt-in.
+type NoopSink struct{}
+
+// IncrCounter implements MetricSink.
+func (NoopSink) IncrCounter(name string, value float64, labels map[string]string) {}
+
+// SetGauge implements MetricSink.
+func (NoopSink) SetGauge(name string, value float64, labels map[string]string) {}
+
+// AddSample implements MetricSink.
+func (NoopSink) AddSample(name string, value float64, labels map[string]string) {}
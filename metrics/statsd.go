@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// StatsDSink sends metrics to a StatsD daemon over UDP, using the usual
+// "key:value|type" wire format with "#tag:value" suffixes for labels.
+type StatsDSink struct {
+	conn net.Conn
+}
+
+// NewStatsDSink dials addr (e.g. "127.0.0.1:8125"). The connection is UDP, so
+// dialing never blocks on the daemon being reachable.
+func NewStatsDSink(addr string) (*StatsDSink, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("dialing statsd at %s: %w", addr, err)
+	}
+	return &StatsDSink{conn: conn}, nil
+}
+
+// IncrCounter implements MetricSink.
+func (s *StatsDSink) IncrCounter(name string, value float64, labels map[string]string) {
+	s.send(name, value, "c", labels)
+}
+
+// SetGauge implements MetricSink.
+func (s *StatsDSink) SetGauge(name string, value float64, labels map[string]string) {
+	s.send(name, value, "g", labels)
+}
+
+// AddSample implements MetricSink.
+func (s *StatsDSink) AddSample(name string, value float64, labels map[string]string) {
+	s.send(name, value, "ms", labels)
+}
+
+func (s *StatsDSink) send(name string, value float64, statType string, labels map[string]string) {
+	line := fmt.Sprintf("%s:%v|%s%s", name, value, statType, tagSuffix(labels))
+	if _, err := s.conn.Write([]byte(line)); err != nil {
+		log.Warn().Err(err).Str("metric", name).Msg("Failed to write statsd metric")
+	}
+}
+
+func tagSuffix(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	tags := make([]string, 0, len(keys))
+	for _, k := range keys {
+		tags = append(tags, k+":"+labels[k])
+	}
+	return "|#" + strings.Join(tags, ",")
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2019 The "MysteriumNetwork/node" Authors.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/mysteriumnetwork/node/metrics"
+)
+
+// defaultSessionMetricName is the metric /metrics/sessions reports when the
+// caller doesn't pick one via ?metric=.
+const defaultSessionMetricName = "session_active"
+
+// sessionMetricNames are the counters/gauges session.Storage reports
+// through its MetricSink and that are queryable via /metrics/sessions.
+var sessionMetricNames = map[string]bool{
+	"session_active":           true,
+	"session_bytes_sent":       true,
+	"session_bytes_received":   true,
+	"session_tokens_earned":    true,
+	"session_duration_seconds": true,
+}
+
+// SessionMetricsEndpoint exposes the in-memory metrics sink so operators can
+// pull session aggregates without scraping bbolt or standing up Prometheus.
+type SessionMetricsEndpoint struct {
+	sink *metrics.InMemorySink
+}
+
+// NewSessionMetricsEndpoint creates an endpoint backed by sink.
+func NewSessionMetricsEndpoint(sink *metrics.InMemorySink) *SessionMetricsEndpoint {
+	return &SessionMetricsEndpoint{sink: sink}
+}
+
+// SessionMetrics responds to GET /metrics/sessions?interval=1m&metric=session_active
+// with the aggregate over the last interval (default one minute), merged
+// across however many of the sink's underlying buckets that window spans.
+// metric defaults to session_active and must be one of sessionMetricNames.
+func (e *SessionMetricsEndpoint) SessionMetrics(resp http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	interval := time.Minute
+	if raw := req.URL.Query().Get("interval"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			http.Error(resp, "invalid interval: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		interval = parsed
+	}
+
+	metric := defaultSessionMetricName
+	if raw := req.URL.Query().Get("metric"); raw != "" {
+		metric = raw
+	}
+	if !sessionMetricNames[metric] {
+		http.Error(resp, "unknown metric: "+metric, http.StatusBadRequest)
+		return
+	}
+
+	aggregate, ok := e.sink.QuerySince(metric, time.Now().Add(-interval))
+	if !ok {
+		resp.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(resp).Encode(struct {
+		Metric    string            `json:"metric"`
+		Interval  string            `json:"interval"`
+		Aggregate metrics.Aggregate `json:"aggregate"`
+	}{
+		Metric:    metric,
+		Interval:  interval.String(),
+		Aggregate: aggregate,
+	})
+}
+
+// AddRoutesForSessionMetrics registers the /metrics/sessions route.
+func AddRoutesForSessionMetrics(router *httprouter.Router, sink *metrics.InMemorySink) {
+	endpoint := NewSessionMetricsEndpoint(sink)
+	router.GET("/metrics/sessions", endpoint.SessionMetrics)
+}